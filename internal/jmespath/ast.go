@@ -0,0 +1,136 @@
+package jmespath
+
+// node is implemented by every AST element produced by the parser. eval
+// evaluates the node against current and returns the values it projects.
+// A nil or empty result means the node matched nothing.
+type node interface {
+	eval(current interface{}) []interface{}
+}
+
+// identifierNode looks up a field or map key by name.
+type identifierNode struct {
+	name string
+}
+
+func (n *identifierNode) eval(current interface{}) []interface{} {
+	v, ok := getField(current, n.name)
+	if !ok {
+		return nil
+	}
+
+	return []interface{}{v}
+}
+
+// currentNode implements the "@" expression, returning the current value
+// unchanged.
+type currentNode struct{}
+
+func (n *currentNode) eval(current interface{}) []interface{} {
+	return []interface{}{current}
+}
+
+// literalNode is a raw string or number literal, as used on the right-hand
+// side of a filter comparison.
+type literalNode struct {
+	value interface{}
+}
+
+func (n *literalNode) eval(interface{}) []interface{} {
+	return []interface{}{n.value}
+}
+
+// subExprNode chains two expressions, evaluating right against every value
+// left projects and flattening the results. This is what makes wildcards and
+// filters compose with the rest of the expression.
+type subExprNode struct {
+	left, right node
+}
+
+func (n *subExprNode) eval(current interface{}) []interface{} {
+	var result []interface{}
+
+	for _, v := range n.left.eval(current) {
+		result = append(result, n.right.eval(v)...)
+	}
+
+	return result
+}
+
+// indexNode indexes into a slice or array. Negative indices count from the
+// end, as in Python.
+type indexNode struct {
+	index int
+}
+
+func (n *indexNode) eval(current interface{}) []interface{} {
+	v, ok := getIndex(current, n.index)
+	if !ok {
+		return nil
+	}
+
+	return []interface{}{v}
+}
+
+// wildcardArrayNode implements "[*]", projecting every element of a slice or
+// array.
+type wildcardArrayNode struct{}
+
+func (n *wildcardArrayNode) eval(current interface{}) []interface{} {
+	return listElements(current)
+}
+
+// wildcardObjectNode implements ".*", projecting every value of a map or
+// struct.
+type wildcardObjectNode struct{}
+
+func (n *wildcardObjectNode) eval(current interface{}) []interface{} {
+	return objectValues(current)
+}
+
+// filterNode implements "[?predicate]", projecting the elements of a slice
+// or array for which pred matches.
+type filterNode struct {
+	pred predicate
+}
+
+func (n *filterNode) eval(current interface{}) []interface{} {
+	var result []interface{}
+
+	for _, v := range listElements(current) {
+		if n.pred.matches(v) {
+			result = append(result, v)
+		}
+	}
+
+	return result
+}
+
+// functionNode calls one of the built-in functions with args evaluated
+// against current. Functions never project: each arg contributes at most
+// its first matched value.
+type functionNode struct {
+	name string
+	args []node
+}
+
+func (n *functionNode) eval(current interface{}) []interface{} {
+	args := make([]interface{}, len(n.args))
+	for i, a := range n.args {
+		args[i] = firstOrNil(a.eval(current))
+	}
+
+	v, ok := callFunction(n.name, args)
+	if !ok {
+		return nil
+	}
+
+	return []interface{}{v}
+}
+
+func firstOrNil(values []interface{}) interface{} {
+	if len(values) == 0 {
+		return nil
+	}
+
+	return values[0]
+}
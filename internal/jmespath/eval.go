@@ -0,0 +1,270 @@
+package jmespath
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// predicate is a single comparison inside a "[?left op right]" filter.
+type predicate struct {
+	left  node
+	op    string
+	right node
+}
+
+func (p predicate) matches(current interface{}) bool {
+	left := firstOrNil(p.left.eval(current))
+	right := firstOrNil(p.right.eval(current))
+
+	switch p.op {
+	case "==":
+		return equalValues(left, right)
+	case "!=":
+		return !equalValues(left, right)
+	case "<", "<=", ">", ">=":
+		lf, lok := toFloat(left)
+		rf, rok := toFloat(right)
+		if !lok || !rok {
+			return false
+		}
+
+		switch p.op {
+		case "<":
+			return lf < rf
+		case "<=":
+			return lf <= rf
+		case ">":
+			return lf > rf
+		default:
+			return lf >= rf
+		}
+	default:
+		return false
+	}
+}
+
+// equalValues compares a and b the way a filter's "==" operator does:
+// numerically if both sides are numbers, structurally otherwise.
+func equalValues(a, b interface{}) bool {
+	if af, aok := toFloat(a); aok {
+		if bf, bok := toFloat(b); bok {
+			return af == bf
+		}
+	}
+
+	return reflect.DeepEqual(a, b)
+}
+
+func toFloat(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case int:
+		return float64(n), true
+	case int8:
+		return float64(n), true
+	case int16:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case uint:
+		return float64(n), true
+	case uint8:
+		return float64(n), true
+	case uint16:
+		return float64(n), true
+	case uint32:
+		return float64(n), true
+	case uint64:
+		return float64(n), true
+	case float32:
+		return float64(n), true
+	case float64:
+		return n, true
+	default:
+		return 0, false
+	}
+}
+
+// indirect dereferences pointers and interfaces until it reaches a concrete
+// value. ok is false if v is nil or a nil pointer/interface was encountered
+// along the way.
+func indirect(v interface{}) (rv reflect.Value, ok bool) {
+	if v == nil {
+		return reflect.Value{}, false
+	}
+
+	rv = reflect.ValueOf(v)
+
+	for rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return reflect.Value{}, false
+		}
+		rv = rv.Elem()
+	}
+
+	return rv, true
+}
+
+func getField(current interface{}, name string) (interface{}, bool) {
+	rv, ok := indirect(current)
+	if !ok {
+		return nil, false
+	}
+
+	switch rv.Kind() {
+	case reflect.Map:
+		key := reflect.ValueOf(name)
+		if !key.Type().AssignableTo(rv.Type().Key()) {
+			return nil, false
+		}
+
+		val := rv.MapIndex(key)
+		if !val.IsValid() {
+			return nil, false
+		}
+
+		return finalize(val)
+	case reflect.Struct:
+		return getStructField(rv, name)
+	default:
+		return nil, false
+	}
+}
+
+func getStructField(rv reflect.Value, name string) (interface{}, bool) {
+	rt := rv.Type()
+
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		if field.PkgPath != "" {
+			continue // unexported
+		}
+
+		tagName, skip := jsonFieldName(field)
+		if skip {
+			continue
+		}
+
+		if tagName == name || (tagName == "" && strings.EqualFold(field.Name, name)) {
+			return finalize(rv.Field(i))
+		}
+	}
+
+	return nil, false
+}
+
+// jsonFieldName returns the name a struct field is addressed by, honoring
+// its `json` tag the way encoding/json would. skip is true for fields
+// tagged `json:"-"`.
+func jsonFieldName(field reflect.StructField) (name string, skip bool) {
+	tag, ok := field.Tag.Lookup("json")
+	if !ok {
+		return "", false
+	}
+
+	if tag == "-" {
+		return "", true
+	}
+
+	parts := strings.Split(tag, ",")
+
+	return parts[0], false
+}
+
+func getIndex(current interface{}, index int) (interface{}, bool) {
+	rv, ok := indirect(current)
+	if !ok || (rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array) {
+		return nil, false
+	}
+
+	n := rv.Len()
+	if index < 0 {
+		index += n
+	}
+	if index < 0 || index >= n {
+		return nil, false
+	}
+
+	return finalize(rv.Index(index))
+}
+
+func listElements(current interface{}) []interface{} {
+	rv, ok := indirect(current)
+	if !ok || (rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array) {
+		return nil
+	}
+
+	result := make([]interface{}, 0, rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		if v, ok := finalize(rv.Index(i)); ok {
+			result = append(result, v)
+		}
+	}
+
+	return result
+}
+
+func objectValues(current interface{}) []interface{} {
+	rv, ok := indirect(current)
+	if !ok {
+		return nil
+	}
+
+	switch rv.Kind() {
+	case reflect.Map:
+		keys := rv.MapKeys()
+		if rv.Type().Key().Kind() == reflect.String {
+			sort.Slice(keys, func(i, j int) bool {
+				return keys[i].String() < keys[j].String()
+			})
+		}
+
+		result := make([]interface{}, 0, len(keys))
+		for _, k := range keys {
+			if v, ok := finalize(rv.MapIndex(k)); ok {
+				result = append(result, v)
+			}
+		}
+
+		return result
+	case reflect.Struct:
+		rt := rv.Type()
+
+		var result []interface{}
+		for i := 0; i < rt.NumField(); i++ {
+			field := rt.Field(i)
+			if field.PkgPath != "" {
+				continue
+			}
+			if _, skip := jsonFieldName(field); skip {
+				continue
+			}
+			if v, ok := finalize(rv.Field(i)); ok {
+				result = append(result, v)
+			}
+		}
+
+		return result
+	default:
+		return nil
+	}
+}
+
+// finalize unwraps v into a plain interface{}, treating nil pointers and
+// interfaces as absent.
+func finalize(v reflect.Value) (interface{}, bool) {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil, false
+		}
+		v = v.Elem()
+	}
+
+	if !v.IsValid() {
+		return nil, false
+	}
+
+	return v.Interface(), true
+}
@@ -0,0 +1,251 @@
+package jmespath
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// callFunction invokes the built-in function name with args, which have
+// already been evaluated against the current node. ok is false if name is
+// unknown or args don't match what the function expects.
+func callFunction(name string, args []interface{}) (interface{}, bool) {
+	switch name {
+	case "length":
+		return fnLength(args)
+	case "keys":
+		return fnKeys(args)
+	case "values":
+		return fnValues(args)
+	case "starts_with":
+		return fnStartsWith(args)
+	case "ends_with":
+		return fnEndsWith(args)
+	case "contains":
+		return fnContains(args)
+	case "type":
+		return fnType(args)
+	case "not_null":
+		return fnNotNull(args)
+	case "join":
+		return fnJoin(args)
+	default:
+		return nil, false
+	}
+}
+
+func fnLength(args []interface{}) (interface{}, bool) {
+	if len(args) != 1 || args[0] == nil {
+		return nil, false
+	}
+
+	if s, ok := args[0].(string); ok {
+		return len([]rune(s)), true
+	}
+
+	rv := reflect.ValueOf(args[0])
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return rv.Len(), true
+	default:
+		return nil, false
+	}
+}
+
+func fnKeys(args []interface{}) (interface{}, bool) {
+	if len(args) != 1 {
+		return nil, false
+	}
+
+	rv, ok := indirect(args[0])
+	if !ok {
+		return nil, false
+	}
+
+	switch rv.Kind() {
+	case reflect.Map:
+		mapKeys := rv.MapKeys()
+		keys := make([]string, len(mapKeys))
+		for i, k := range mapKeys {
+			keys[i] = fmt.Sprint(k.Interface())
+		}
+		sort.Strings(keys)
+
+		result := make([]interface{}, len(keys))
+		for i, k := range keys {
+			result[i] = k
+		}
+		return result, true
+	case reflect.Struct:
+		rt := rv.Type()
+
+		var keys []interface{}
+		for i := 0; i < rt.NumField(); i++ {
+			field := rt.Field(i)
+			if field.PkgPath != "" {
+				continue
+			}
+
+			name, skip := jsonFieldName(field)
+			if skip {
+				continue
+			}
+			if name == "" {
+				name = field.Name
+			}
+
+			keys = append(keys, name)
+		}
+		return keys, true
+	default:
+		return nil, false
+	}
+}
+
+func fnValues(args []interface{}) (interface{}, bool) {
+	if len(args) != 1 {
+		return nil, false
+	}
+
+	rv, ok := indirect(args[0])
+	if !ok || (rv.Kind() != reflect.Map && rv.Kind() != reflect.Struct) {
+		return nil, false
+	}
+
+	return objectValues(args[0]), true
+}
+
+func fnStartsWith(args []interface{}) (interface{}, bool) {
+	s, prefix, ok := twoStrings(args)
+	if !ok {
+		return nil, false
+	}
+
+	return strings.HasPrefix(s, prefix), true
+}
+
+func fnEndsWith(args []interface{}) (interface{}, bool) {
+	s, suffix, ok := twoStrings(args)
+	if !ok {
+		return nil, false
+	}
+
+	return strings.HasSuffix(s, suffix), true
+}
+
+func fnContains(args []interface{}) (interface{}, bool) {
+	if len(args) != 2 {
+		return nil, false
+	}
+
+	if s, ok := args[0].(string); ok {
+		sub, ok := args[1].(string)
+		if !ok {
+			return nil, false
+		}
+		return strings.Contains(s, sub), true
+	}
+
+	rv := reflect.ValueOf(args[0])
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return nil, false
+	}
+
+	for i := 0; i < rv.Len(); i++ {
+		if equalValues(rv.Index(i).Interface(), args[1]) {
+			return true, true
+		}
+	}
+
+	return false, true
+}
+
+func fnType(args []interface{}) (interface{}, bool) {
+	if len(args) != 1 {
+		return nil, false
+	}
+
+	return typeName(args[0]), true
+}
+
+func typeName(v interface{}) string {
+	if v == nil {
+		return "null"
+	}
+
+	switch v.(type) {
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	}
+
+	if _, ok := toFloat(v); ok {
+		return "number"
+	}
+
+	switch reflect.ValueOf(v).Kind() {
+	case reflect.Slice, reflect.Array:
+		return "array"
+	case reflect.Map, reflect.Struct:
+		return "object"
+	default:
+		return "string"
+	}
+}
+
+func fnNotNull(args []interface{}) (interface{}, bool) {
+	for _, a := range args {
+		if a != nil {
+			return a, true
+		}
+	}
+
+	return nil, false
+}
+
+func fnJoin(args []interface{}) (interface{}, bool) {
+	if len(args) != 2 {
+		return nil, false
+	}
+
+	sep, ok := args[0].(string)
+	if !ok {
+		return nil, false
+	}
+
+	rv := reflect.ValueOf(args[1])
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return nil, false
+	}
+
+	parts := make([]string, 0, rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		s, ok := rv.Index(i).Interface().(string)
+		if !ok {
+			return nil, false
+		}
+		parts = append(parts, s)
+	}
+
+	return strings.Join(parts, sep), true
+}
+
+func twoStrings(args []interface{}) (a, b string, ok bool) {
+	if len(args) != 2 {
+		return "", "", false
+	}
+
+	a, ok = args[0].(string)
+	if !ok {
+		return "", "", false
+	}
+
+	b, ok = args[1].(string)
+	if !ok {
+		return "", "", false
+	}
+
+	return a, b, true
+}
@@ -0,0 +1,64 @@
+// Package jmespath implements a small subset of JMESPath
+// (https://jmespath.org) for navigating Go values via reflection: identifier
+// and index access, wildcards, filter expressions, and a handful of built-in
+// functions (length, keys, values, starts_with, ends_with, contains, type,
+// not_null, join). It is not a general-purpose JMESPath implementation and
+// is only meant to back (*optional.Optional).Select and friends.
+package jmespath
+
+import "fmt"
+
+// Expression is a parsed expression that can be evaluated against arbitrary
+// Go values.
+type Expression struct {
+	raw  string
+	root node
+}
+
+// Compile parses expr and returns the resulting *Expression. It returns an
+// error if expr is not valid in the supported subset.
+func Compile(expr string) (*Expression, error) {
+	root, err := parse(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Expression{raw: expr, root: root}, nil
+}
+
+// MustCompile is like Compile but panics if expr cannot be parsed.
+func MustCompile(expr string) *Expression {
+	e, err := Compile(expr)
+	if err != nil {
+		panic(fmt.Sprintf("jmespath: %s", err))
+	}
+
+	return e
+}
+
+// String returns the expression string the *Expression was compiled from.
+func (e *Expression) String() string {
+	return e.raw
+}
+
+// Search evaluates the expression against data and returns the first value
+// it matches. ok is false if nothing matched.
+func (e *Expression) Search(data interface{}) (value interface{}, ok bool) {
+	results := e.root.eval(data)
+	if len(results) == 0 {
+		return nil, false
+	}
+
+	return results[0], true
+}
+
+// SearchAll evaluates the expression against data and returns every value it
+// matches. It returns nil if nothing matched.
+func (e *Expression) SearchAll(data interface{}) []interface{} {
+	results := e.root.eval(data)
+	if len(results) == 0 {
+		return nil
+	}
+
+	return results
+}
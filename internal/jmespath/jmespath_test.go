@@ -0,0 +1,117 @@
+package jmespath
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type address struct {
+	City string `json:"city"`
+}
+
+type person struct {
+	Name      string    `json:"name"`
+	Age       int       `json:"age"`
+	Addresses []address `json:"addresses"`
+	Tags      []string  `json:"tags"`
+}
+
+func TestSearch(t *testing.T) {
+	p := person{
+		Name: "alice",
+		Age:  30,
+		Addresses: []address{
+			{City: "berlin"},
+			{City: "hamburg"},
+		},
+		Tags: []string{"admin", "ops"},
+	}
+
+	tests := []struct {
+		expr  string
+		value interface{}
+		ok    bool
+	}{
+		{"name", "alice", true},
+		{"addresses[0].city", "berlin", true},
+		{"addresses[-1].city", "hamburg", true},
+		{"addresses[5].city", nil, false},
+		{"missing", nil, false},
+		{"missing.field", nil, false},
+		{"tags[0]", "admin", true},
+		{"length(tags)", 2, true},
+		{"length(name)", 5, true},
+		{"starts_with(name, 'ali')", true, true},
+		{"ends_with(name, 'ice')", true, true},
+		{"contains(tags, 'ops')", true, true},
+		{"contains(tags, 'dev')", false, true},
+		{"type(name)", "string", true},
+		{"type(age)", "number", true},
+		{"type(tags)", "array", true},
+		{"not_null(missing, name)", "alice", true},
+		{"join(',', tags)", "admin,ops", true},
+		{"addresses[?city == 'hamburg'].city", "hamburg", true},
+		{"addresses[?city == 'nowhere'].city", nil, false},
+		{"age", 30, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			e := MustCompile(tt.expr)
+
+			value, ok := e.Search(p)
+
+			assert.Equal(t, tt.ok, ok)
+			if tt.ok {
+				assert.Equal(t, tt.value, value)
+			}
+		})
+	}
+}
+
+func TestSearchAll(t *testing.T) {
+	p := person{
+		Name: "alice",
+		Addresses: []address{
+			{City: "berlin"},
+			{City: "hamburg"},
+		},
+	}
+
+	e := MustCompile("addresses[*].city")
+
+	assert.Equal(t, []interface{}{"berlin", "hamburg"}, e.SearchAll(p))
+
+	e = MustCompile("missing[*].city")
+
+	assert.Nil(t, e.SearchAll(p))
+}
+
+func TestSearchMap(t *testing.T) {
+	data := map[string]interface{}{
+		"items": []interface{}{
+			map[string]interface{}{"name": "foo", "age": 12},
+			map[string]interface{}{"name": "bar", "age": 34},
+		},
+	}
+
+	e := MustCompile("items[?age > 20].name")
+
+	value, ok := e.Search(data)
+
+	assert.True(t, ok)
+	assert.Equal(t, "bar", value)
+}
+
+func TestCompileError(t *testing.T) {
+	_, err := Compile("addresses[")
+	assert.Error(t, err)
+
+	_, err = Compile("foo bar")
+	assert.Error(t, err)
+
+	assert.Panics(t, func() {
+		MustCompile("[")
+	})
+}
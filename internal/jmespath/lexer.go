@@ -0,0 +1,176 @@
+package jmespath
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// lexer scans a JMESPath-style expression into tokens.
+type lexer struct {
+	input []rune
+	pos   int
+}
+
+func newLexer(expr string) *lexer {
+	return &lexer{input: []rune(expr)}
+}
+
+// tokenize scans expr into a sequence of tokens terminated by a single
+// tokEOF entry.
+func tokenize(expr string) ([]token, error) {
+	l := newLexer(expr)
+
+	var tokens []token
+
+	for {
+		tok, err := l.next()
+		if err != nil {
+			return nil, err
+		}
+
+		tokens = append(tokens, tok)
+
+		if tok.kind == tokEOF {
+			return tokens, nil
+		}
+	}
+}
+
+func (l *lexer) next() (token, error) {
+	l.skipSpace()
+
+	if l.pos >= len(l.input) {
+		return token{kind: tokEOF}, nil
+	}
+
+	r := l.input[l.pos]
+
+	switch {
+	case r == '.':
+		l.pos++
+		return token{kind: tokDot}, nil
+	case r == '[':
+		l.pos++
+		return token{kind: tokLBracket}, nil
+	case r == ']':
+		l.pos++
+		return token{kind: tokRBracket}, nil
+	case r == '(':
+		l.pos++
+		return token{kind: tokLParen}, nil
+	case r == ')':
+		l.pos++
+		return token{kind: tokRParen}, nil
+	case r == '*':
+		l.pos++
+		return token{kind: tokStar}, nil
+	case r == '?':
+		l.pos++
+		return token{kind: tokQuestion}, nil
+	case r == ',':
+		l.pos++
+		return token{kind: tokComma}, nil
+	case r == '@':
+		l.pos++
+		return token{kind: tokAt}, nil
+	case r == '-':
+		l.pos++
+		return token{kind: tokMinus}, nil
+	case r == '=' && l.peekAt(1) == '=':
+		l.pos += 2
+		return token{kind: tokEQ}, nil
+	case r == '!' && l.peekAt(1) == '=':
+		l.pos += 2
+		return token{kind: tokNE}, nil
+	case r == '<' && l.peekAt(1) == '=':
+		l.pos += 2
+		return token{kind: tokLE}, nil
+	case r == '<':
+		l.pos++
+		return token{kind: tokLT}, nil
+	case r == '>' && l.peekAt(1) == '=':
+		l.pos += 2
+		return token{kind: tokGE}, nil
+	case r == '>':
+		l.pos++
+		return token{kind: tokGT}, nil
+	case r == '\'':
+		return l.scanString()
+	case unicode.IsDigit(r):
+		return l.scanNumber()
+	case isIdentStart(r):
+		return l.scanIdent()
+	default:
+		return token{}, fmt.Errorf("jmespath: unexpected character %q at position %d", r, l.pos)
+	}
+}
+
+func (l *lexer) peekAt(offset int) rune {
+	if l.pos+offset >= len(l.input) {
+		return 0
+	}
+	return l.input[l.pos+offset]
+}
+
+func (l *lexer) skipSpace() {
+	for l.pos < len(l.input) && unicode.IsSpace(l.input[l.pos]) {
+		l.pos++
+	}
+}
+
+func (l *lexer) scanString() (token, error) {
+	l.pos++ // consume opening quote
+
+	var sb strings.Builder
+
+	for {
+		if l.pos >= len(l.input) {
+			return token{}, fmt.Errorf("jmespath: unterminated string literal")
+		}
+
+		r := l.input[l.pos]
+
+		if r == '\'' {
+			l.pos++
+			return token{kind: tokString, text: sb.String()}, nil
+		}
+
+		if r == '\\' && l.peekAt(1) == '\'' {
+			sb.WriteRune('\'')
+			l.pos += 2
+			continue
+		}
+
+		sb.WriteRune(r)
+		l.pos++
+	}
+}
+
+func (l *lexer) scanNumber() (token, error) {
+	start := l.pos
+
+	for l.pos < len(l.input) && (unicode.IsDigit(l.input[l.pos]) || l.input[l.pos] == '.') {
+		l.pos++
+	}
+
+	return token{kind: tokNumber, text: string(l.input[start:l.pos])}, nil
+}
+
+func (l *lexer) scanIdent() (token, error) {
+	start := l.pos
+
+	for l.pos < len(l.input) && isIdentPart(l.input[l.pos]) {
+		l.pos++
+	}
+
+	return token{kind: tokIdent, text: string(l.input[start:l.pos])}, nil
+}
+
+func isIdentStart(r rune) bool {
+	return unicode.IsLetter(r) || r == '_'
+}
+
+func isIdentPart(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_'
+}
@@ -0,0 +1,315 @@
+package jmespath
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// parser builds an AST from the token stream produced by tokenize. It
+// implements a small recursive-descent parser for the supported subset of
+// JMESPath: identifiers, sub-expressions, index and wildcard expressions,
+// filters with equality/comparison operators, and function calls.
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func parse(expr string) (node, error) {
+	tokens, err := tokenize(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+
+	n, err := p.parseExpression()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.peek().kind != tokEOF {
+		return nil, fmt.Errorf("jmespath: unexpected trailing input at token %d", p.pos)
+	}
+
+	return n, nil
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) advance() token {
+	tok := p.tokens[p.pos]
+	if p.pos < len(p.tokens)-1 {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *parser) expect(kind tokenKind) (token, error) {
+	if p.peek().kind != kind {
+		return token{}, fmt.Errorf("jmespath: unexpected token at position %d", p.pos)
+	}
+	return p.advance(), nil
+}
+
+func (p *parser) parseExpression() (node, error) {
+	n, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		switch p.peek().kind {
+		case tokDot:
+			p.advance()
+			if p.peek().kind == tokStar {
+				p.advance()
+				n = &subExprNode{left: n, right: &wildcardObjectNode{}}
+				continue
+			}
+
+			rhs, err := p.parseDotted()
+			if err != nil {
+				return nil, err
+			}
+			n = &subExprNode{left: n, right: rhs}
+		case tokLBracket:
+			rhs, err := p.parseBracket()
+			if err != nil {
+				return nil, err
+			}
+			n = &subExprNode{left: n, right: rhs}
+		default:
+			return n, nil
+		}
+	}
+}
+
+// parsePrimary parses the first element of an expression: the current-node
+// token (@), an identifier/function call, or a leading bracket expression.
+func (p *parser) parsePrimary() (node, error) {
+	switch p.peek().kind {
+	case tokAt:
+		p.advance()
+		return &currentNode{}, nil
+	case tokIdent:
+		return p.parseDotted()
+	case tokLBracket:
+		return p.parseBracket()
+	default:
+		return nil, fmt.Errorf("jmespath: unexpected token at position %d", p.pos)
+	}
+}
+
+// parseDotted parses an identifier or function call, as found at the start
+// of an expression or right after a '.'.
+func (p *parser) parseDotted() (node, error) {
+	tok, err := p.expect(tokIdent)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.peek().kind == tokLParen {
+		return p.parseFunctionCall(tok.text)
+	}
+
+	return &identifierNode{name: tok.text}, nil
+}
+
+func (p *parser) parseFunctionCall(name string) (node, error) {
+	if _, err := p.expect(tokLParen); err != nil {
+		return nil, err
+	}
+
+	var args []node
+
+	for p.peek().kind != tokRParen {
+		arg, err := p.parseArgument()
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+
+		if p.peek().kind == tokComma {
+			p.advance()
+			continue
+		}
+		break
+	}
+
+	if _, err := p.expect(tokRParen); err != nil {
+		return nil, err
+	}
+
+	return &functionNode{name: name, args: args}, nil
+}
+
+// parseArgument parses a single function argument, which may be a literal or
+// a sub-expression evaluated against the current node.
+func (p *parser) parseArgument() (node, error) {
+	switch p.peek().kind {
+	case tokString, tokNumber, tokMinus:
+		return p.parseLiteral()
+	default:
+		return p.parseExpression()
+	}
+}
+
+func (p *parser) parseLiteral() (node, error) {
+	neg := false
+	if p.peek().kind == tokMinus {
+		p.advance()
+		neg = true
+	}
+
+	tok := p.advance()
+
+	switch tok.kind {
+	case tokString:
+		if neg {
+			return nil, fmt.Errorf("jmespath: unary minus is not valid before a string literal")
+		}
+		return &literalNode{value: tok.text}, nil
+	case tokNumber:
+		f, err := strconv.ParseFloat(tok.text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("jmespath: invalid number %q", tok.text)
+		}
+		if neg {
+			f = -f
+		}
+		return &literalNode{value: f}, nil
+	default:
+		return nil, fmt.Errorf("jmespath: expected literal at token %d", p.pos)
+	}
+}
+
+func (p *parser) parseBracket() (node, error) {
+	if _, err := p.expect(tokLBracket); err != nil {
+		return nil, err
+	}
+
+	var n node
+
+	switch p.peek().kind {
+	case tokStar:
+		p.advance()
+		n = &wildcardArrayNode{}
+	case tokQuestion:
+		p.advance()
+		pred, err := p.parsePredicate()
+		if err != nil {
+			return nil, err
+		}
+		n = &filterNode{pred: pred}
+	case tokMinus, tokNumber:
+		i, err := p.parseIndexLiteral()
+		if err != nil {
+			return nil, err
+		}
+		n = &indexNode{index: i}
+	default:
+		return nil, fmt.Errorf("jmespath: unexpected token inside [] at position %d", p.pos)
+	}
+
+	if _, err := p.expect(tokRBracket); err != nil {
+		return nil, err
+	}
+
+	return n, nil
+}
+
+func (p *parser) parseIndexLiteral() (int, error) {
+	neg := false
+	if p.peek().kind == tokMinus {
+		p.advance()
+		neg = true
+	}
+
+	tok, err := p.expect(tokNumber)
+	if err != nil {
+		return 0, err
+	}
+
+	i, err := strconv.Atoi(tok.text)
+	if err != nil {
+		return 0, fmt.Errorf("jmespath: invalid index %q", tok.text)
+	}
+	if neg {
+		i = -i
+	}
+
+	return i, nil
+}
+
+func (p *parser) parsePredicate() (predicate, error) {
+	left, err := p.parseFilterOperand()
+	if err != nil {
+		return predicate{}, err
+	}
+
+	op, err := p.parseComparator()
+	if err != nil {
+		return predicate{}, err
+	}
+
+	right, err := p.parseFilterOperand()
+	if err != nil {
+		return predicate{}, err
+	}
+
+	return predicate{left: left, op: op, right: right}, nil
+}
+
+// parseFilterOperand parses one side of a filter comparison: a literal, the
+// current node, or a (possibly dotted) field expression evaluated relative
+// to the element being filtered.
+func (p *parser) parseFilterOperand() (node, error) {
+	switch p.peek().kind {
+	case tokString, tokNumber, tokMinus:
+		return p.parseLiteral()
+	case tokAt:
+		p.advance()
+		return &currentNode{}, nil
+	case tokIdent:
+		n, err := p.parseDotted()
+		if err != nil {
+			return nil, err
+		}
+
+		for p.peek().kind == tokDot {
+			p.advance()
+			rhs, err := p.parseDotted()
+			if err != nil {
+				return nil, err
+			}
+			n = &subExprNode{left: n, right: rhs}
+		}
+
+		return n, nil
+	default:
+		return nil, fmt.Errorf("jmespath: unexpected token in filter at position %d", p.pos)
+	}
+}
+
+func (p *parser) parseComparator() (string, error) {
+	tok := p.advance()
+
+	switch tok.kind {
+	case tokEQ:
+		return "==", nil
+	case tokNE:
+		return "!=", nil
+	case tokLT:
+		return "<", nil
+	case tokLE:
+		return "<=", nil
+	case tokGT:
+		return ">", nil
+	case tokGE:
+		return ">=", nil
+	default:
+		return "", fmt.Errorf("jmespath: expected comparator at token %d", p.pos)
+	}
+}
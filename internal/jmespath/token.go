@@ -0,0 +1,35 @@
+package jmespath
+
+// tokenKind identifies the lexical class of a token.
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokIdent
+	tokNumber
+	tokString
+	tokDot
+	tokLBracket
+	tokRBracket
+	tokLParen
+	tokRParen
+	tokStar
+	tokQuestion
+	tokComma
+	tokAt
+	tokMinus
+	tokEQ
+	tokNE
+	tokLT
+	tokLE
+	tokGT
+	tokGE
+)
+
+// token is a single lexical token produced by the lexer. text holds the
+// decoded identifier, number or string literal, and is unused for
+// punctuation tokens.
+type token struct {
+	kind tokenKind
+	text string
+}
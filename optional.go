@@ -42,7 +42,12 @@ func Empty() *Optional {
 
 // Equals returns true if other is equal to o. Equality is implied if:
 // 1) other is o (pointer equality)
-// 2) other and o are both of type *Optional and have the same value.
+// 2) other and o are both of type *Optional and have equal values.
+//
+// Values are always compared with reflect.DeepEqual, so e.g. two *Optional
+// wrapping distinct pointers to equal values are equal, and comparison never
+// panics for slices, maps or funcs obtained via OfNilable. Use EqualsFunc to
+// plug in a different comparison, e.g. proto.Equal or cmp.Equal.
 func (o *Optional) Equals(other interface{}) bool {
 	if other == o {
 		return true
@@ -53,7 +58,28 @@ func (o *Optional) Equals(other interface{}) bool {
 		return false
 	}
 
-	return opt.value == o.value
+	return valuesEqual(o.value, opt.value)
+}
+
+// EqualsFunc is like Equals but uses eq to compare the wrapped values instead
+// of the built-in comparison. eq is only invoked if other is an *Optional and
+// both o and other have a value; otherwise EqualsFunc returns false (if other
+// is empty and o is not, or vice versa) or true (if both are empty).
+func (o *Optional) EqualsFunc(other interface{}, eq func(a, b interface{}) bool) bool {
+	if other == o {
+		return true
+	}
+
+	opt, ok := other.(*Optional)
+	if !ok {
+		return false
+	}
+
+	if o.IsEmpty() || opt.IsEmpty() {
+		return o.IsEmpty() == opt.IsEmpty()
+	}
+
+	return eq(o.value, opt.value)
 }
 
 // Filter matches the optional value (if present) against predicate and returns
@@ -237,6 +263,15 @@ func (o *Optional) String() string {
 	return "Optional.Empty"
 }
 
+// valuesEqual reports whether a and b are equal using reflect.DeepEqual,
+// which already enforces dynamic-type identity before comparing values. A
+// value's reflect.Kind being comparable isn't enough to use == safely here:
+// a struct holding an interface{} field has a comparable type even when that
+// field holds a slice, map or func, and == would panic on it at runtime.
+func valuesEqual(a, b interface{}) bool {
+	return reflect.DeepEqual(a, b)
+}
+
 // isNil returns true if value is a typed or untyped nil value.
 func isNil(value interface{}) bool {
 	if value == nil {
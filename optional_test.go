@@ -1,6 +1,7 @@
 package optional
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -11,6 +12,41 @@ func TestEquals(t *testing.T) {
 	assert.True(t, Of("foo").Equals(Of("foo")))
 	assert.False(t, Of("foo").Equals("foo"))
 	assert.False(t, Of("foo").Equals(Of("bar")))
+
+	assert.True(t, Of([]int{1, 2}).Equals(Of([]int{1, 2})))
+	assert.False(t, Of([]int{1, 2}).Equals(Of([]int{1, 3})))
+
+	assert.True(t, Of(map[string]int{"a": 1}).Equals(Of(map[string]int{"a": 1})))
+	assert.False(t, Of(map[string]int{"a": 1}).Equals(Of(map[string]int{"a": 2})))
+
+	type withSlice struct {
+		Values []int
+	}
+
+	assert.True(t, Of(withSlice{Values: []int{1, 2}}).Equals(Of(withSlice{Values: []int{1, 2}})))
+	assert.False(t, Of(withSlice{Values: []int{1, 2}}).Equals(Of(withSlice{Values: []int{1, 3}})))
+
+	type box struct {
+		V interface{}
+	}
+
+	assert.NotPanics(t, func() {
+		assert.True(t, Of(box{V: []int{1, 2}}).Equals(Of(box{V: []int{1, 2}})))
+		assert.False(t, Of(box{V: []int{1, 2}}).Equals(Of(box{V: []int{1, 3}})))
+	})
+}
+
+func TestEqualsFunc(t *testing.T) {
+	eq := func(a, b interface{}) bool {
+		return strings.EqualFold(a.(string), b.(string))
+	}
+
+	assert.True(t, Of("FOO").EqualsFunc(Of("foo"), eq))
+	assert.False(t, Of("FOO").EqualsFunc(Of("bar"), eq))
+	assert.True(t, Empty().EqualsFunc(Empty(), eq))
+	assert.False(t, Of("foo").EqualsFunc(Empty(), eq))
+	assert.False(t, Empty().EqualsFunc(Of("foo"), eq))
+	assert.False(t, Of("foo").EqualsFunc("foo", eq))
 }
 
 func TestFilter(t *testing.T) {
@@ -0,0 +1,97 @@
+package optional
+
+import (
+	"sync"
+
+	"github.com/martinohmann/optional/internal/jmespath"
+)
+
+// queryCache caches *Query values built by Select and SelectAll, keyed by
+// expression string, so that repeated calls with the same expression don't
+// reparse it.
+var queryCache sync.Map // map[string]*Query
+
+// Query is a pre-compiled JMESPath-style expression for use with
+// (*Optional).Query and (*Optional).QueryAll. Compile it once with
+// MustCompileQuery and reuse it to skip the cache lookup Select and SelectAll
+// do on every call.
+type Query struct {
+	expr *jmespath.Expression
+}
+
+// MustCompileQuery compiles expr into a *Query. It panics if expr is not a
+// valid expression in the subset documented on Select.
+func MustCompileQuery(expr string) *Query {
+	return &Query{expr: jmespath.MustCompile(expr)}
+}
+
+// Select treats the optional value as a JSON-like tree of maps, slices,
+// structs (matched by their `json` tags) and pointers, and returns an
+// *Optional describing the value found at expr, e.g.
+// opt.Select("addresses[0].city"). It returns Empty() if the optional has no
+// value or if any step of expr misses.
+//
+// expr is a subset of JMESPath (https://jmespath.org): identifiers (foo),
+// sub-expressions (a.b), indices ([0], [-1]), wildcards ([*], .*), filter
+// expressions ([?field == 'x']), and the functions length, keys, values,
+// starts_with, ends_with, contains, type, not_null and join.
+//
+// Select compiles and caches expr the first time it is used; call
+// MustCompileQuery and Query directly to avoid the cache lookup.
+func (o *Optional) Select(expr string) *Optional {
+	return o.Query(cachedQuery(expr))
+}
+
+// Query is like Select but takes a pre-compiled *Query instead of looking up
+// expr in the cache of compiled queries.
+func (o *Optional) Query(q *Query) *Optional {
+	if o.IsEmpty() {
+		return o
+	}
+
+	value, ok := q.expr.Search(o.value)
+	if !ok {
+		return Empty()
+	}
+
+	return OfNilable(value)
+}
+
+// SelectAll is like Select, but for expressions that project multiple
+// results (wildcards and filters). It returns one *Optional per value expr
+// matched, or nil if the optional has no value or expr matched nothing.
+func (o *Optional) SelectAll(expr string) []*Optional {
+	return o.QueryAll(cachedQuery(expr))
+}
+
+// QueryAll is like SelectAll but takes a pre-compiled *Query instead of
+// looking up expr in the cache of compiled queries.
+func (o *Optional) QueryAll(q *Query) []*Optional {
+	if o.IsEmpty() {
+		return nil
+	}
+
+	values := q.expr.SearchAll(o.value)
+	if len(values) == 0 {
+		return nil
+	}
+
+	opts := make([]*Optional, len(values))
+	for i, value := range values {
+		opts[i] = OfNilable(value)
+	}
+
+	return opts
+}
+
+func cachedQuery(expr string) *Query {
+	if v, ok := queryCache.Load(expr); ok {
+		return v.(*Query)
+	}
+
+	q := MustCompileQuery(expr)
+
+	actual, _ := queryCache.LoadOrStore(expr, q)
+
+	return actual.(*Query)
+}
@@ -0,0 +1,64 @@
+package optional
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type selectAddress struct {
+	City string `json:"city"`
+}
+
+type selectPerson struct {
+	Name      string          `json:"name"`
+	Addresses []selectAddress `json:"addresses"`
+}
+
+func TestSelect(t *testing.T) {
+	p := selectPerson{
+		Name: "alice",
+		Addresses: []selectAddress{
+			{City: "berlin"},
+			{City: "hamburg"},
+		},
+	}
+
+	assert.Equal(t, Of("alice"), Of(p).Select("name"))
+	assert.Equal(t, Of("berlin"), Of(p).Select("addresses[0].city"))
+	assert.Equal(t, Empty(), Of(p).Select("addresses[5].city"))
+	assert.Equal(t, Empty(), Of(p).Select("missing.field"))
+	assert.Equal(t, Empty(), Empty().Select("name"))
+
+	data := map[string]interface{}{
+		"items": []interface{}{
+			map[string]interface{}{"name": "foo"},
+			map[string]interface{}{"name": "bar"},
+		},
+	}
+
+	assert.Equal(t, Of("bar"), Of(data).Select("items[?name == 'bar'].name"))
+}
+
+func TestSelectAll(t *testing.T) {
+	p := selectPerson{
+		Name: "alice",
+		Addresses: []selectAddress{
+			{City: "berlin"},
+			{City: "hamburg"},
+		},
+	}
+
+	assert.Equal(t, []*Optional{Of("berlin"), Of("hamburg")}, Of(p).SelectAll("addresses[*].city"))
+	assert.Nil(t, Of(p).SelectAll("missing[*]"))
+	assert.Nil(t, Empty().SelectAll("addresses[*].city"))
+}
+
+func TestQuery(t *testing.T) {
+	q := MustCompileQuery("name")
+
+	p := selectPerson{Name: "alice"}
+
+	assert.Equal(t, Of("alice"), Of(p).Query(q))
+	assert.Equal(t, Empty(), Empty().Query(q))
+}